@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// isWaylandSession reports whether the current session is running under a
+// Wayland compositor.
+func isWaylandSession() bool {
+	return os.Getenv("WAYLAND_DISPLAY") != ""
+}
+
+// ClipboardProvider reads and writes the system clipboard. Platform-specific
+// implementations live in clipboard_darwin.go, clipboard_windows.go and
+// clipboard_linux.go.
+type ClipboardProvider interface {
+	// Copy writes text to the system clipboard.
+	Copy(text string) error
+	// Read returns the current contents of the system clipboard.
+	Read() (string, error)
+}
+
+// Clipboard exposes clipboard operations backed by a platform-specific
+// ClipboardProvider, plus the key injection needed to paste into the
+// currently focused window.
+type Clipboard struct {
+	provider ClipboardProvider
+}
+
+// NewClipboard creates a new clipboard instance, selecting the provider for
+// the current platform (and, on Linux, the current session type).
+func NewClipboard() *Clipboard {
+	return &Clipboard{provider: newClipboardProvider()}
+}
+
+// CopyToClipboard copies text to the clipboard
+func (c *Clipboard) CopyToClipboard(text string) error {
+	return c.provider.Copy(text)
+}
+
+// ReadClipboard returns the current clipboard contents so callers can
+// round-trip what was pasted.
+func (c *Clipboard) ReadClipboard() (string, error) {
+	return c.provider.Read()
+}
+
+// Paste simulates the paste keystroke (Ctrl+V) in the focused window.
+// injectKeyCombo is implemented per-platform: natively via robotgo on
+// macOS/Windows/X11, and via wtype or ydotool on Wayland (with no XWayland
+// fallback when neither is installed).
+func (c *Clipboard) Paste() error {
+	return injectKeyCombo("ctrl+v")
+}
+
+// PasteWithReturn pastes text and adds a newline
+func (c *Clipboard) PasteWithReturn(text string) error {
+	if err := c.CopyToClipboard(text); err != nil {
+		return err
+	}
+
+	if err := c.Paste(); err != nil {
+		return fmt.Errorf("failed to paste: %v", err)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	if err := injectKeyCombo("Return"); err != nil {
+		return fmt.Errorf("failed to press enter: %v", err)
+	}
+	return nil
+}
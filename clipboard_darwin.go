@@ -0,0 +1,39 @@
+//go:build darwin
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"os/exec"
+)
+
+// macOSClipboard implements ClipboardProvider using pbcopy/pbpaste.
+type macOSClipboard struct{}
+
+func newClipboardProvider() ClipboardProvider {
+	return &macOSClipboard{}
+}
+
+func (p *macOSClipboard) Copy(text string) error {
+	cmd := exec.Command("pbcopy")
+	pipeReader, pipeWriter := io.Pipe()
+	cmd.Stdin = pipeReader
+
+	go func() {
+		defer pipeWriter.Close()
+		pipeWriter.Write([]byte(text))
+	}()
+
+	return cmd.Run()
+}
+
+func (p *macOSClipboard) Read() (string, error) {
+	var out bytes.Buffer
+	cmd := exec.Command("pbpaste")
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
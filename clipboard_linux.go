@@ -0,0 +1,93 @@
+//go:build linux
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os/exec"
+)
+
+func newClipboardProvider() ClipboardProvider {
+	if isWaylandSession() {
+		if _, err := exec.LookPath("wl-copy"); err == nil {
+			return &waylandClipboard{}
+		}
+	}
+
+	if _, err := exec.LookPath("xclip"); err == nil {
+		return &x11Clipboard{copyCmd: []string{"xclip", "-selection", "clipboard"}, pasteCmd: []string{"xclip", "-selection", "clipboard", "-o"}}
+	}
+	if _, err := exec.LookPath("xsel"); err == nil {
+		return &x11Clipboard{copyCmd: []string{"xsel", "--clipboard", "--input"}, pasteCmd: []string{"xsel", "--clipboard", "--output"}}
+	}
+
+	// No known clipboard tool found; keep the xclip-shaped error behaviour
+	// callers previously depended on.
+	return &x11Clipboard{copyCmd: []string{"xclip", "-selection", "clipboard"}, pasteCmd: []string{"xclip", "-selection", "clipboard", "-o"}}
+}
+
+// x11Clipboard implements ClipboardProvider by shelling out to xclip (or
+// xsel, as a fallback when xclip is not installed).
+type x11Clipboard struct {
+	copyCmd  []string
+	pasteCmd []string
+}
+
+func (p *x11Clipboard) Copy(text string) error {
+	if _, err := exec.LookPath(p.copyCmd[0]); err != nil {
+		return fmt.Errorf("%s is not installed", p.copyCmd[0])
+	}
+
+	cmd := exec.Command(p.copyCmd[0], p.copyCmd[1:]...)
+	pipeReader, pipeWriter := io.Pipe()
+	cmd.Stdin = pipeReader
+
+	go func() {
+		defer pipeWriter.Close()
+		pipeWriter.Write([]byte(text))
+	}()
+
+	return cmd.Run()
+}
+
+func (p *x11Clipboard) Read() (string, error) {
+	if _, err := exec.LookPath(p.pasteCmd[0]); err != nil {
+		return "", fmt.Errorf("%s is not installed", p.pasteCmd[0])
+	}
+
+	var out bytes.Buffer
+	cmd := exec.Command(p.pasteCmd[0], p.pasteCmd[1:]...)
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// waylandClipboard implements ClipboardProvider using wl-copy/wl-paste.
+type waylandClipboard struct{}
+
+func (p *waylandClipboard) Copy(text string) error {
+	cmd := exec.Command("wl-copy")
+	pipeReader, pipeWriter := io.Pipe()
+	cmd.Stdin = pipeReader
+
+	go func() {
+		defer pipeWriter.Close()
+		pipeWriter.Write([]byte(text))
+	}()
+
+	return cmd.Run()
+}
+
+func (p *waylandClipboard) Read() (string, error) {
+	var out bytes.Buffer
+	cmd := exec.Command("wl-paste", "--no-newline")
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,89 @@
+//go:build windows
+
+package main
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// windowsClipboard implements ClipboardProvider using the Win32 clipboard
+// API (OpenClipboard/SetClipboardData/GetClipboardData with CF_UNICODETEXT),
+// the same approach used by atotto/clipboard and zyedidia/clipboard.
+type windowsClipboard struct{}
+
+func newClipboardProvider() ClipboardProvider {
+	return &windowsClipboard{}
+}
+
+const cfUnicodeText = 13
+
+var (
+	user32               = syscall.NewLazyDLL("user32.dll")
+	kernel32             = syscall.NewLazyDLL("kernel32.dll")
+	procOpenClipboard    = user32.NewProc("OpenClipboard")
+	procCloseClipboard   = user32.NewProc("CloseClipboard")
+	procEmptyClipboard   = user32.NewProc("EmptyClipboard")
+	procSetClipboardData = user32.NewProc("SetClipboardData")
+	procGetClipboardData = user32.NewProc("GetClipboardData")
+	procGlobalAlloc      = kernel32.NewProc("GlobalAlloc")
+	procGlobalFree       = kernel32.NewProc("GlobalFree")
+	procGlobalLock       = kernel32.NewProc("GlobalLock")
+	procGlobalUnlock     = kernel32.NewProc("GlobalUnlock")
+)
+
+func (p *windowsClipboard) Copy(text string) error {
+	utf16, err := syscall.UTF16FromString(text)
+	if err != nil {
+		return err
+	}
+
+	if ret, _, err := procOpenClipboard.Call(0); ret == 0 {
+		return fmt.Errorf("OpenClipboard failed: %v", err)
+	}
+	defer procCloseClipboard.Call()
+
+	procEmptyClipboard.Call()
+
+	size := len(utf16) * 2
+	h, _, err := procGlobalAlloc.Call(0x0002 /* GMEM_MOVEABLE */, uintptr(size))
+	if h == 0 {
+		return fmt.Errorf("GlobalAlloc failed: %v", err)
+	}
+
+	locked, _, err := procGlobalLock.Call(h)
+	if locked == 0 {
+		procGlobalFree.Call(h)
+		return fmt.Errorf("GlobalLock failed: %v", err)
+	}
+	copy((*[1 << 20]uint16)(unsafe.Pointer(locked))[:len(utf16)], utf16)
+	procGlobalUnlock.Call(h)
+
+	if ret, _, err := procSetClipboardData.Call(cfUnicodeText, h); ret == 0 {
+		procGlobalFree.Call(h)
+		return fmt.Errorf("SetClipboardData failed: %v", err)
+	}
+
+	return nil
+}
+
+func (p *windowsClipboard) Read() (string, error) {
+	if ret, _, err := procOpenClipboard.Call(0); ret == 0 {
+		return "", fmt.Errorf("OpenClipboard failed: %v", err)
+	}
+	defer procCloseClipboard.Call()
+
+	h, _, err := procGetClipboardData.Call(cfUnicodeText)
+	if h == 0 {
+		return "", fmt.Errorf("GetClipboardData failed: %v", err)
+	}
+
+	locked, _, err := procGlobalLock.Call(h)
+	if locked == 0 {
+		return "", fmt.Errorf("GlobalLock failed: %v", err)
+	}
+	defer procGlobalUnlock.Call(h)
+
+	return syscall.UTF16ToString((*[1 << 20]uint16)(unsafe.Pointer(locked))[:]), nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/go-vgo/robotgo"
+)
+
+// parseKeyCombo splits an xdotool-style combo ("ctrl+shift+p") into its
+// modifier keys and the final key.
+func parseKeyCombo(combo string) (mods []string, key string) {
+	parts := strings.Split(combo, "+")
+	return parts[:len(parts)-1], parts[len(parts)-1]
+}
+
+// injectKeyComboRobotgo synthesizes combo via robotgo, which drives native
+// input APIs on macOS, Windows and X11.
+func injectKeyComboRobotgo(combo string) error {
+	mods, key := parseKeyCombo(combo)
+	key = strings.ToLower(key)
+	if key == "return" {
+		key = "enter"
+	}
+
+	args := make([]interface{}, len(mods))
+	for i, mod := range mods {
+		args[i] = strings.ToLower(mod)
+	}
+
+	return robotgo.KeyTap(key, args...)
+}
@@ -0,0 +1,79 @@
+//go:build linux
+
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// injectKeyCombo synthesizes a key combo in the focused window, preferring
+// native Wayland input backends over X11 key injection when running under
+// Wayland.
+func injectKeyCombo(combo string) error {
+	if isWaylandSession() {
+		return waylandKeyCombo(combo)
+	}
+	return injectKeyComboRobotgo(combo)
+}
+
+// waylandKeyCombo synthesizes a key combo (e.g. "ctrl+v", "Return") on a
+// native Wayland compositor. wtype is preferred; ydotool (which talks to the
+// uinput daemon and so also works without XWayland) is used when wtype is
+// not installed. This replaces falling back to xdotool/XWayland, which only
+// works when the focused surface accepts XWayland input.
+func waylandKeyCombo(combo string) error {
+	if _, err := exec.LookPath("wtype"); err == nil {
+		return wtypeKeyCombo(combo)
+	}
+	if _, err := exec.LookPath("ydotool"); err == nil {
+		args := append([]string{"key"}, strings.Fields(ydotoolCombo(combo))...)
+		return exec.Command("ydotool", args...).Run()
+	}
+	return fmt.Errorf("no wayland input backend available (install wtype or ydotool)")
+}
+
+// wtypeKeyCombo translates an xdotool-style combo ("ctrl+v", "Return") into
+// wtype's modifier/key flags and runs it.
+func wtypeKeyCombo(combo string) error {
+	parts := strings.Split(combo, "+")
+	key := parts[len(parts)-1]
+	mods := parts[:len(parts)-1]
+
+	args := []string{}
+	for _, mod := range mods {
+		args = append(args, "-M", mod)
+	}
+	args = append(args, "-k", wtypeKeyName(key))
+	for _, mod := range mods {
+		args = append(args, "-m", mod)
+	}
+
+	return exec.Command("wtype", args...).Run()
+}
+
+// wtypeKeyName maps xdotool key names to the names wtype expects.
+func wtypeKeyName(key string) string {
+	if key == "Return" {
+		return "Return"
+	}
+	return strings.ToLower(key)
+}
+
+// ydotoolCombo translates an xdotool-style combo into ydotool's
+// "keycode:state" list. ydotool identifies keys by Linux input event
+// keycodes rather than names, so only the combos this plugin actually
+// issues are supported.
+func ydotoolCombo(combo string) string {
+	switch combo {
+	case "ctrl+v":
+		return "29:1 47:1 47:0 29:0"
+	case "Return":
+		return "28:1 28:0"
+	case "ctrl+shift+p":
+		return "29:1 42:1 25:1 25:0 42:0 29:0"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package main
+
+// injectKeyCombo synthesizes a key combo in the focused window using
+// robotgo, which drives native input APIs on macOS and Windows.
+func injectKeyCombo(combo string) error {
+	return injectKeyComboRobotgo(combo)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"sync"
+)
+
+// reopenableFile wraps an *os.File and reopens itself at the same path on
+// SIGHUP (the client9/reopen pattern), so an external log rotator - or
+// `kill -HUP` - can rotate this plugin's log file without restarting the
+// Voicify process that hosts it.
+type reopenableFile struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+}
+
+func newReopenableFile(path string) (*reopenableFile, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &reopenableFile{path: path, file: f}, nil
+}
+
+func (r *reopenableFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Write(p)
+}
+
+func (r *reopenableFile) Reopen() error {
+	f, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	old := r.file
+	r.file = f
+	r.mu.Unlock()
+
+	return old.Close()
+}
+
+// SetLogFile directs logging to path, reopening it on SIGHUP where the
+// platform supports that signal (see logfile_unix.go / logfile_windows.go).
+func SetLogFile(path string) error {
+	rf, err := newReopenableFile(path)
+	if err != nil {
+		return err
+	}
+
+	SetOutput(rf)
+	watchForReopenSignal(rf)
+
+	return nil
+}
@@ -0,0 +1,20 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// watchForReopenSignal reopens rf whenever the process receives SIGHUP.
+func watchForReopenSignal(rf *reopenableFile) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	go func() {
+		for range sighup {
+			rf.Reopen()
+		}
+	}()
+}
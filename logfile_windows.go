@@ -0,0 +1,7 @@
+//go:build windows
+
+package main
+
+// watchForReopenSignal is a no-op on Windows, which has no SIGHUP; log
+// rotation there needs an explicit SetLogFile call instead.
+func watchForReopenSignal(rf *reopenableFile) {}
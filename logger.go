@@ -0,0 +1,245 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+)
+
+// LogLevel mirrors slog's levels so existing call sites (SetLogLevel,
+// method signatures below) don't need to change.
+type LogLevel int
+
+const (
+	// Debug level for detailed information
+	LevelDebug LogLevel = iota
+	// Info level for general information
+	LevelInfo
+	// Warn level for warnings
+	LevelWarn
+	// Error level for errors
+	LevelError
+)
+
+func (l LogLevel) slogLevel() slog.Level {
+	switch l {
+	case LevelDebug:
+		return slog.LevelDebug
+	case LevelWarn:
+		return slog.LevelWarn
+	case LevelError:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// LogHook is invoked for every record logged through Logger, letting
+// Voicify subscribe to this plugin's log events over the plugin API
+// instead of only scraping stdout.
+type LogHook func(level LogLevel, message string, fields map[string]any)
+
+var (
+	levelVar = func() *slog.LevelVar {
+		v := new(slog.LevelVar)
+		v.Set(LevelInfo.slogLevel())
+		return v
+	}()
+
+	handlerMu  sync.Mutex
+	baseLogger = slog.New(newHandler(os.Stdout, logFormatFromEnv(os.Stdout)))
+
+	hooksMu sync.Mutex
+	hooks   []LogHook
+)
+
+func init() {
+	if level, ok := parseLogLevel(os.Getenv("VOICIFY_LOG_LEVEL")); ok {
+		SetLogLevel(level)
+	}
+}
+
+// parseLogLevel parses VOICIFY_LOG_LEVEL values such as "debug" or "WARN".
+func parseLogLevel(s string) (LogLevel, bool) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return LevelDebug, true
+	case "info":
+		return LevelInfo, true
+	case "warn", "warning":
+		return LevelWarn, true
+	case "error":
+		return LevelError, true
+	default:
+		return LevelInfo, false
+	}
+}
+
+// logFormatFromEnv honours VOICIFY_LOG_FORMAT ("json"/"text"), defaulting to
+// text when w is a TTY and JSON otherwise, so a host process can consume
+// events programmatically without the plugin having to know it's being
+// piped. w is the writer logs are actually being sent to (e.g. the file
+// passed to SetOutput), not always os.Stdout.
+func logFormatFromEnv(w io.Writer) string {
+	switch strings.ToLower(os.Getenv("VOICIFY_LOG_FORMAT")) {
+	case "json":
+		return "json"
+	case "text":
+		return "text"
+	}
+	if isTerminal(w) {
+		return "text"
+	}
+	return "json"
+}
+
+// isTerminal reports whether w is a character-device file such as a TTY.
+// Non-*os.File writers (e.g. an in-memory buffer) are never terminals.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+func newHandler(w io.Writer, format string) slog.Handler {
+	opts := &slog.HandlerOptions{Level: levelVar}
+	if format == "json" {
+		return slog.NewJSONHandler(w, opts)
+	}
+	return slog.NewTextHandler(w, opts)
+}
+
+// Logger provides logging functionality for plugins. It holds only the
+// extra fields attached via WithField/WithFields; the underlying slog
+// logger is looked up fresh on every call so SetOutput/SetLogLevel affect
+// loggers that were already constructed (matching the previous package-var
+// based implementation).
+type Logger struct {
+	fields []any
+}
+
+// NewLogger creates a new logger instance
+func NewLogger() *Logger {
+	return &Logger{}
+}
+
+// WithField returns a Logger that annotates every subsequent record with
+// key=value, e.g. logger.WithField("window", title).Debug("...").
+func (l *Logger) WithField(key string, value any) *Logger {
+	return &Logger{fields: append(append([]any{}, l.fields...), key, value)}
+}
+
+// WithFields returns a Logger that annotates every subsequent record with
+// the given fields.
+func (l *Logger) WithFields(fields map[string]any) *Logger {
+	args := make([]any, 0, len(fields)*2)
+	for k, v := range fields {
+		args = append(args, k, v)
+	}
+	return &Logger{fields: append(append([]any{}, l.fields...), args...)}
+}
+
+// slogger returns the current base logger with this Logger's fields applied.
+func (l *Logger) slogger() *slog.Logger {
+	handlerMu.Lock()
+	base := baseLogger
+	handlerMu.Unlock()
+
+	if len(l.fields) == 0 {
+		return base
+	}
+	return base.With(l.fields...)
+}
+
+// fieldMap converts the accumulated key/value pairs from
+// WithField/WithFields into a map for hook subscribers.
+func (l *Logger) fieldMap() map[string]any {
+	if len(l.fields) == 0 {
+		return nil
+	}
+	fields := make(map[string]any, len(l.fields)/2)
+	for i := 0; i+1 < len(l.fields); i += 2 {
+		key, ok := l.fields[i].(string)
+		if !ok {
+			continue
+		}
+		fields[key] = l.fields[i+1]
+	}
+	return fields
+}
+
+func (l *Logger) log(level LogLevel, message string) {
+	l.slogger().Log(context.Background(), level.slogLevel(), message)
+	runHooks(level, message, l.fieldMap())
+}
+
+// Debug logs a debug message
+func (l *Logger) Debug(message string) { l.log(LevelDebug, message) }
+
+// Debugf logs a formatted debug message
+func (l *Logger) Debugf(format string, args ...any) { l.log(LevelDebug, fmt.Sprintf(format, args...)) }
+
+// Info logs an info message
+func (l *Logger) Info(message string) { l.log(LevelInfo, message) }
+
+// Infof logs a formatted info message
+func (l *Logger) Infof(format string, args ...any) { l.log(LevelInfo, fmt.Sprintf(format, args...)) }
+
+// Warn logs a warning message
+func (l *Logger) Warn(message string) { l.log(LevelWarn, message) }
+
+// Warnf logs a formatted warning message
+func (l *Logger) Warnf(format string, args ...any) { l.log(LevelWarn, fmt.Sprintf(format, args...)) }
+
+// Error logs an error message with an optional error
+func (l *Logger) Error(message string, err error) {
+	lg := l.slogger()
+	if err != nil {
+		lg.Error(message, "error", err)
+	} else {
+		lg.Error(message)
+	}
+	runHooks(LevelError, message, l.fieldMap())
+}
+
+// Errorf logs a formatted error message
+func (l *Logger) Errorf(format string, args ...any) { l.log(LevelError, fmt.Sprintf(format, args...)) }
+
+// SetLogLevel sets the global log level for this logger
+func SetLogLevel(level LogLevel) {
+	levelVar.Set(level.slogLevel())
+}
+
+// SetOutput sets the output writer for this logger
+func SetOutput(w io.Writer) {
+	handlerMu.Lock()
+	defer handlerMu.Unlock()
+	baseLogger = slog.New(newHandler(w, logFormatFromEnv(w)))
+}
+
+// Subscribe registers a hook invoked for every record logged from this
+// point on, letting Voicify subscribe to this plugin's log records over the
+// plugin API.
+func Subscribe(hook LogHook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	hooks = append(hooks, hook)
+}
+
+func runHooks(level LogLevel, message string, fields map[string]any) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	for _, hook := range hooks {
+		hook(level, message, fields)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ActionMatch declaratively describes when an action should fire, evaluated
+// against the focused window and the transcription before an action's
+// side-effecting handler runs.
+//
+// This is intentionally a vscode-local type, not an extension of
+// pluginapi.ActionMetadata: pluginapi is an external, vendored-by-reference
+// dependency (github.com/dooshek/voicify/pkg/pluginapi) with no source in
+// this repo, so this plugin can't add fields to it or move evaluation into
+// the plugin manager. ActionMatch is evaluated here, inside Action.Execute,
+// as a deliberate partial implementation of the request's ask — other
+// plugins can't reuse this declarative gating until the equivalent fields
+// and dispatch logic land in pluginapi and the manager itself, which is
+// out of scope for this repo.
+type ActionMatch struct {
+	// WindowTitleRegex, if set, must match the focused window's title.
+	WindowTitleRegex string
+	// AppNameRegex, if set, must match the focused window's process name.
+	AppNameRegex string
+	// TranscriptionRegex, if set, must match the spoken transcription.
+	TranscriptionRegex string
+	// TranscriptionExcludeRegex, if set, must NOT match the spoken
+	// transcription. Used to keep a catch-all action from also firing on
+	// phrases a more specific action already claims.
+	TranscriptionExcludeRegex string
+	// RequireFocused requires a focused window to be resolvable at all.
+	RequireFocused bool
+}
+
+// evaluate reports whether the match rules are satisfied for the given
+// focused window and transcription. A regex that fails to compile is
+// treated as non-matching rather than panicking.
+func (m ActionMatch) evaluate(window *WindowInfo, transcription string) bool {
+	if m.RequireFocused && window == nil {
+		return false
+	}
+
+	if m.AppNameRegex != "" {
+		if window == nil || !matchesRegex(m.AppNameRegex, normalizeAppName(window.AppName)) {
+			return false
+		}
+	}
+
+	if m.WindowTitleRegex != "" {
+		if window == nil || !matchesRegex(m.WindowTitleRegex, window.Title) {
+			return false
+		}
+	}
+
+	if m.TranscriptionRegex != "" && !matchesRegex(m.TranscriptionRegex, transcription) {
+		return false
+	}
+
+	if m.TranscriptionExcludeRegex != "" && matchesRegex(m.TranscriptionExcludeRegex, transcription) {
+		return false
+	}
+
+	return true
+}
+
+// normalizeAppName strips a Windows ".exe" suffix and lowercases the
+// process name so AppNameRegex patterns don't need to account for platform
+// casing/suffix differences (e.g. "Code.exe" on Windows vs "code" on X11).
+func normalizeAppName(appName string) string {
+	return strings.ToLower(strings.TrimSuffix(appName, ".exe"))
+}
+
+func matchesRegex(pattern, value string) bool {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		logger.Errorf("invalid match regex %q: %v", pattern, err)
+		return false
+	}
+	return re.MatchString(value)
+}
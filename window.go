@@ -0,0 +1,31 @@
+package main
+
+// WindowProvider resolves the currently focused window. Platform-specific
+// implementations live in window_darwin.go, window_windows.go and
+// window_linux.go (which in turn delegates to window_wayland_linux.go under
+// Wayland).
+type WindowProvider interface {
+	GetFocusedWindow() (*WindowInfo, error)
+}
+
+// WindowInfo contains information about the focused window
+type WindowInfo struct {
+	Title   string
+	AppName string
+}
+
+// Window resolves the focused window via a platform-specific WindowProvider.
+type Window struct {
+	provider WindowProvider
+}
+
+// NewWindow creates a new window instance, selecting the provider for the
+// current platform (and, on Linux, the current session type).
+func NewWindow() *Window {
+	return &Window{provider: newWindowProvider()}
+}
+
+// GetFocusedWindow gets the currently focused window.
+func (w *Window) GetFocusedWindow() (*WindowInfo, error) {
+	return w.provider.GetFocusedWindow()
+}
@@ -0,0 +1,43 @@
+//go:build darwin
+
+package main
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// macOSWindowProvider resolves the focused window via AppleScript/System
+// Events.
+type macOSWindowProvider struct{}
+
+func newWindowProvider() WindowProvider {
+	return &macOSWindowProvider{}
+}
+
+func (p *macOSWindowProvider) GetFocusedWindow() (*WindowInfo, error) {
+	appName, err := runAppleScript(`tell application "System Events" to get name of first application process whose frontmost is true`)
+	if err != nil {
+		return nil, err
+	}
+
+	title, err := runAppleScript(`tell application "System Events" to tell (first application process whose frontmost is true) to get value of attribute "AXTitle" of window 1`)
+	if err != nil {
+		// Some apps don't expose a window title via accessibility; fall
+		// back to just the process name.
+		title = appName
+	}
+
+	return &WindowInfo{
+		Title:   strings.TrimSpace(title),
+		AppName: strings.TrimSpace(appName),
+	}, nil
+}
+
+func runAppleScript(script string) (string, error) {
+	out, err := exec.Command("osascript", "-e", script).Output()
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
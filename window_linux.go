@@ -0,0 +1,79 @@
+//go:build linux
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// x11WindowProvider resolves the focused window via xdotool, plus an
+// xprop _NET_WM_PID lookup to resolve the owning process name.
+type x11WindowProvider struct{}
+
+// waylandWindowProvider resolves the focused window via compositor-specific
+// backends (sway, GNOME Shell, KWin).
+type waylandWindowProvider struct{}
+
+func newWindowProvider() WindowProvider {
+	if isWaylandSession() {
+		return &waylandWindowProvider{}
+	}
+	return &x11WindowProvider{}
+}
+
+func (p *x11WindowProvider) GetFocusedWindow() (*WindowInfo, error) {
+	windowID, err := exec.Command("xdotool", "getactivewindow").Output()
+	if err != nil {
+		return nil, err
+	}
+	id := strings.TrimSpace(string(windowID))
+
+	windowName, err := exec.Command("xdotool", "getwindowname", id).Output()
+	if err != nil {
+		return nil, err
+	}
+
+	appName, err := appNameFromWindowID(id)
+	if err != nil {
+		appName = ""
+	}
+
+	return &WindowInfo{
+		Title:   strings.TrimSpace(string(windowName)),
+		AppName: appName,
+	}, nil
+}
+
+// appNameFromWindowID resolves the owning process's executable name for an
+// X11 window ID via xprop's _NET_WM_PID and /proc/<pid>/comm.
+func appNameFromWindowID(id string) (string, error) {
+	out, err := exec.Command("xprop", "-id", id, "_NET_WM_PID").Output()
+	if err != nil {
+		return "", err
+	}
+
+	parts := strings.Split(string(out), "=")
+	if len(parts) != 2 {
+		return "", fmt.Errorf("unexpected xprop output: %s", out)
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return "", err
+	}
+
+	comm, err := os.ReadFile(fmt.Sprintf("/proc/%d/comm", pid))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(comm)), nil
+}
+
+func (p *waylandWindowProvider) GetFocusedWindow() (*WindowInfo, error) {
+	return getFocusedWindowWayland()
+}
@@ -0,0 +1,145 @@
+//go:build linux
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// gnomeEvalResult matches gdbus's rendering of org.gnome.Shell.Eval's
+// (success, result) tuple, e.g. (true, '"My Title\nCode"'). The inner
+// payload is itself a JSON-quoted string, since GNOME Shell JSON-encodes
+// Eval results before returning them.
+var gnomeEvalResult = regexp.MustCompile(`^\(true, '(.*)'\)$`)
+
+// getFocusedWindowWayland resolves the focused window on Wayland
+// compositors that don't expose titles through xdotool, trying each known
+// compositor backend in turn.
+func getFocusedWindowWayland() (*WindowInfo, error) {
+	if info, err := swayFocusedWindow(); err == nil {
+		return info, nil
+	}
+	if info, err := gnomeFocusedWindow(); err == nil {
+		return info, nil
+	}
+	if info, err := kdeFocusedWindow(); err == nil {
+		return info, nil
+	}
+	return nil, fmt.Errorf("no wayland window detection backend available")
+}
+
+// swayNode is the subset of `swaymsg -t get_tree` we care about.
+type swayNode struct {
+	Focused  bool       `json:"focused"`
+	Name     string     `json:"name"`
+	AppID    string     `json:"app_id"`
+	Nodes    []swayNode `json:"nodes"`
+	Floating []swayNode `json:"floating_nodes"`
+}
+
+func findFocusedSwayNode(node swayNode) (swayNode, bool) {
+	if node.Focused {
+		return node, true
+	}
+	for _, child := range node.Nodes {
+		if found, ok := findFocusedSwayNode(child); ok {
+			return found, true
+		}
+	}
+	for _, child := range node.Floating {
+		if found, ok := findFocusedSwayNode(child); ok {
+			return found, true
+		}
+	}
+	return swayNode{}, false
+}
+
+// swayFocusedWindow queries sway's window tree over its IPC socket.
+func swayFocusedWindow() (*WindowInfo, error) {
+	out, err := exec.Command("swaymsg", "-t", "get_tree").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var root swayNode
+	if err := json.Unmarshal(out, &root); err != nil {
+		return nil, err
+	}
+
+	node, ok := findFocusedSwayNode(root)
+	if !ok {
+		return nil, fmt.Errorf("no focused sway node found")
+	}
+
+	return &WindowInfo{Title: node.Name, AppName: node.AppID}, nil
+}
+
+// gnomeFocusedWindow queries the focused window's title and WM class from
+// GNOME Shell over D-Bus using the org.gnome.Shell.Eval method, which only
+// succeeds when GNOME Shell allows Eval (unsafe mode). The Window Calls
+// extension's D-Bus interface is not queried.
+func gnomeFocusedWindow() (*WindowInfo, error) {
+	script := `(function() {
+		let w = global.display.focus_window;
+		if (!w) return '';
+		return w.title + '\n' + w.wm_class;
+	})()`
+	out, err := exec.Command("gdbus", "call", "--session",
+		"--dest", "org.gnome.Shell",
+		"--object-path", "/org/gnome/Shell",
+		"--method", "org.gnome.Shell.Eval", script).Output()
+	if err != nil {
+		return nil, err
+	}
+
+	match := gnomeEvalResult.FindStringSubmatch(strings.TrimSpace(string(out)))
+	if match == nil {
+		return nil, fmt.Errorf("no focused window reported by gnome shell")
+	}
+
+	var payload string
+	if err := json.Unmarshal([]byte(match[1]), &payload); err != nil {
+		return nil, fmt.Errorf("unexpected gnome shell eval result: %w", err)
+	}
+	if payload == "" {
+		return nil, fmt.Errorf("no focused window reported by gnome shell")
+	}
+
+	lines := strings.SplitN(payload, "\n", 2)
+	title := lines[0]
+	appName := ""
+	if len(lines) == 2 {
+		appName = lines[1]
+	}
+
+	return &WindowInfo{Title: title, AppName: appName}, nil
+}
+
+// kdeFocusedWindow resolves the active window's caption and resource class
+// via kdotool (an xdotool-alike for KWin/Wayland). KWin's own D-Bus
+// queryWindowInfo method is an interactive picker that spawns a crosshair
+// and blocks on a user click, so it can't be used to detect a focused
+// window on every transcription.
+func kdeFocusedWindow() (*WindowInfo, error) {
+	windowID, err := exec.Command("kdotool", "getactivewindow").Output()
+	if err != nil {
+		return nil, err
+	}
+	id := strings.TrimSpace(string(windowID))
+
+	title, err := exec.Command("kdotool", "getwindowname", id).Output()
+	if err != nil {
+		return nil, err
+	}
+
+	appName := ""
+	if classOut, err := exec.Command("kdotool", "getwindowclassname", id).Output(); err == nil {
+		appName = strings.TrimSpace(string(classOut))
+	}
+
+	return &WindowInfo{Title: strings.TrimSpace(string(title)), AppName: appName}, nil
+}
@@ -0,0 +1,71 @@
+//go:build windows
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"syscall"
+	"unsafe"
+)
+
+// windowsWindowProvider resolves the focused window via the Win32 API:
+// GetForegroundWindow + GetWindowText for the title, and
+// GetWindowThreadProcessId + OpenProcess/QueryFullProcessImageName for the
+// owning executable name.
+type windowsWindowProvider struct{}
+
+func newWindowProvider() WindowProvider {
+	return &windowsWindowProvider{}
+}
+
+var (
+	procGetForegroundWindow       = user32.NewProc("GetForegroundWindow")
+	procGetWindowTextW            = user32.NewProc("GetWindowTextW")
+	procGetWindowThreadProcessId  = user32.NewProc("GetWindowThreadProcessId")
+	procOpenProcess               = kernel32.NewProc("OpenProcess")
+	procQueryFullProcessImageName = kernel32.NewProc("QueryFullProcessImageNameW")
+	procCloseHandle               = kernel32.NewProc("CloseHandle")
+)
+
+const (
+	processQueryLimitedInformation = 0x1000
+)
+
+func (p *windowsWindowProvider) GetFocusedWindow() (*WindowInfo, error) {
+	hwnd, _, _ := procGetForegroundWindow.Call()
+	if hwnd == 0 {
+		return nil, fmt.Errorf("no foreground window")
+	}
+
+	titleBuf := make([]uint16, 512)
+	procGetWindowTextW.Call(hwnd, uintptr(unsafe.Pointer(&titleBuf[0])), uintptr(len(titleBuf)))
+	title := syscall.UTF16ToString(titleBuf)
+
+	var pid uint32
+	procGetWindowThreadProcessId.Call(hwnd, uintptr(unsafe.Pointer(&pid)))
+
+	appName, err := processExecutableName(pid)
+	if err != nil {
+		appName = ""
+	}
+
+	return &WindowInfo{Title: title, AppName: appName}, nil
+}
+
+func processExecutableName(pid uint32) (string, error) {
+	handle, _, _ := procOpenProcess.Call(processQueryLimitedInformation, 0, uintptr(pid))
+	if handle == 0 {
+		return "", fmt.Errorf("OpenProcess failed for pid %d", pid)
+	}
+	defer procCloseHandle.Call(handle)
+
+	buf := make([]uint16, 1024)
+	size := uint32(len(buf))
+	ret, _, err := procQueryFullProcessImageName.Call(handle, 0, uintptr(unsafe.Pointer(&buf[0])), uintptr(unsafe.Pointer(&size)))
+	if ret == 0 {
+		return "", fmt.Errorf("QueryFullProcessImageName failed: %v", err)
+	}
+
+	return filepath.Base(syscall.UTF16ToString(buf[:size])), nil
+}